@@ -0,0 +1,76 @@
+// sg-common/cmd/sgsecrets/export.go
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+
+	"github.com/google/subcommands"
+	"gopkg.in/yaml.v3"
+)
+
+type exportCmd struct {
+	format string
+}
+
+func (*exportCmd) Name() string     { return "export" }
+func (*exportCmd) Synopsis() string { return "dump every secret in the project as env, json or yaml" }
+func (*exportCmd) Usage() string    { return "export --format=env|json|yaml\n" }
+
+func (c *exportCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&c.format, "format", "env", "output format: env, json or yaml")
+}
+
+func (c *exportCmd) Execute(ctx context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	switch c.format {
+	case "env", "json", "yaml":
+	default:
+		return fail("unsupported --format %q: must be env, json or yaml", c.format)
+	}
+
+	writer, err := newWriter()
+	if err != nil {
+		return fail("failed to create gcp writer: %v", err)
+	}
+
+	names, err := writer.ListSecrets("")
+	if err != nil {
+		return fail("failed to list secrets: %v", err)
+	}
+
+	// Read through the same GCP client used to list, not secrets.GetFetcher():
+	// GetFetcher reflects whatever backend the calling service is configured
+	// for (possibly none), which has nothing to do with the GCP project
+	// we're exporting secrets from.
+	values := make(map[string]string, len(names))
+	for _, name := range names {
+		value, err := writer.GetSecret(ctx, name)
+		if err != nil {
+			return fail("failed to get secret %s: %v", name, err)
+		}
+		values[name] = value
+	}
+
+	switch c.format {
+	case "env":
+		for _, name := range names {
+			fmt.Printf("%s=%s\n", name, values[name])
+		}
+	case "json":
+		encoded, err := json.MarshalIndent(values, "", "  ")
+		if err != nil {
+			return fail("failed to encode json: %v", err)
+		}
+		fmt.Println(string(encoded))
+	case "yaml":
+		encoded, err := yaml.Marshal(values)
+		if err != nil {
+			return fail("failed to encode yaml: %v", err)
+		}
+		fmt.Print(string(encoded))
+	}
+
+	return subcommands.ExitSuccess
+}
@@ -0,0 +1,33 @@
+// sg-common/cmd/sgsecrets/get.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/google/subcommands"
+
+	"github.com/PiccoloMondoC/sg-common/secrets"
+)
+
+type getCmd struct{}
+
+func (*getCmd) Name() string     { return "get" }
+func (*getCmd) Synopsis() string { return "print the value of a secret" }
+func (*getCmd) Usage() string    { return "get <name>\n" }
+func (*getCmd) SetFlags(*flag.FlagSet) {}
+
+func (*getCmd) Execute(ctx context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 1 {
+		return fail("get: expected exactly one secret name")
+	}
+
+	value, err := secrets.GetFetcher().GetSecret(ctx, f.Arg(0))
+	if err != nil {
+		return fail("failed to get secret: %v", err)
+	}
+
+	fmt.Println(value)
+	return subcommands.ExitSuccess
+}
@@ -0,0 +1,39 @@
+// sg-common/cmd/sgsecrets/list.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/google/subcommands"
+)
+
+type listCmd struct {
+	filter string
+}
+
+func (*listCmd) Name() string     { return "list" }
+func (*listCmd) Synopsis() string { return "list secrets in the project" }
+func (*listCmd) Usage() string    { return "list [--filter=]\n" }
+
+func (c *listCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&c.filter, "filter", "", "Secret Manager filter expression, e.g. \"name:prod-*\"")
+}
+
+func (c *listCmd) Execute(_ context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	writer, err := newWriter()
+	if err != nil {
+		return fail("failed to create gcp writer: %v", err)
+	}
+
+	names, err := writer.ListSecrets(c.filter)
+	if err != nil {
+		return fail("failed to list secrets: %v", err)
+	}
+
+	for _, name := range names {
+		fmt.Println(name)
+	}
+	return subcommands.ExitSuccess
+}
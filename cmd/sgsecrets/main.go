@@ -0,0 +1,69 @@
+// sg-common/cmd/sgsecrets/main.go
+/*
+   sgsecrets is a small CLI for working with the secrets a service reads
+   through sg-common/secrets. "sgsecrets get" reads through
+   secrets.GetFetcher(), so it sees exactly what the service sees. Every
+   other subcommand (list, versions, rotate, export, set, sync) reads and
+   writes through the GCP client from newWriter, since secrets.SecretWriter
+   is GCP-only today and those subcommands operate on a specific project
+   rather than whatever backend the calling service happens to be
+   configured for.
+
+   Usage:
+
+	sgsecrets get API_KEY
+	sgsecrets set API_KEY @./new-key.txt
+	sgsecrets list --filter=name:prod-*
+	sgsecrets versions API_KEY
+	sgsecrets rotate API_KEY
+	sgsecrets export --format=json
+	sgsecrets sync --from=env --to=gcp
+*/
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/subcommands"
+
+	"github.com/PiccoloMondoC/sg-common/secrets"
+)
+
+func main() {
+	subcommands.Register(subcommands.HelpCommand(), "")
+	subcommands.Register(subcommands.FlagsCommand(), "")
+	subcommands.Register(subcommands.CommandsCommand(), "")
+	subcommands.Register(&getCmd{}, "")
+	subcommands.Register(&setCmd{}, "")
+	subcommands.Register(&listCmd{}, "")
+	subcommands.Register(&versionsCmd{}, "")
+	subcommands.Register(&rotateCmd{}, "")
+	subcommands.Register(&exportCmd{}, "")
+	subcommands.Register(&syncCmd{}, "")
+
+	flag.Parse()
+	ctx := context.Background()
+	os.Exit(int(subcommands.Execute(ctx)))
+}
+
+// newWriter builds the write-side client for the GCP project configured
+// via GCP_PROJECT_ID / GCP_CREDENTIALS_FILE. Every mutating subcommand
+// needs one, since SecretWriter is currently GCP-only. It returns the
+// concrete *secrets.GcpSecretManagerFetcher, rather than the narrower
+// secrets.SecretWriter interface, so callers that also need to read
+// secret values (export) can do so through this same client instead of
+// secrets.GetFetcher(), which may be pointed at a different backend
+// entirely (or none, if USE_SECRET_MANAGER/SECRET_PROVIDER aren't set).
+func newWriter() (*secrets.GcpSecretManagerFetcher, error) {
+	projectID := os.Getenv("GCP_PROJECT_ID")
+	credentialsFile := os.Getenv("GCP_CREDENTIALS_FILE")
+	return secrets.NewGcpSecretManagerFetcher(projectID, credentialsFile)
+}
+
+func fail(format string, args ...interface{}) subcommands.ExitStatus {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	return subcommands.ExitFailure
+}
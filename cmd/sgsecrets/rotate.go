@@ -0,0 +1,51 @@
+// sg-common/cmd/sgsecrets/rotate.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/google/subcommands"
+)
+
+type rotateCmd struct{}
+
+func (*rotateCmd) Name() string     { return "rotate" }
+func (*rotateCmd) Synopsis() string { return "add a new version of a secret, reading the value from stdin" }
+func (*rotateCmd) Usage() string {
+	return "rotate <name>   (reads the new value from stdin)\n"
+}
+func (*rotateCmd) SetFlags(*flag.FlagSet) {}
+
+func (*rotateCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 1 {
+		return fail("rotate: expected exactly one secret name")
+	}
+	name := f.Arg(0)
+
+	// Read the whole of stdin, not just its first line: secrets like TLS
+	// private keys or service-account JSON span multiple lines, and the
+	// same @file path in "set" reads its source in full too.
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fail("failed to read new value from stdin: %v", err)
+	}
+	value := strings.TrimRight(string(data), "\n")
+
+	writer, err := newWriter()
+	if err != nil {
+		return fail("failed to create gcp writer: %v", err)
+	}
+
+	version, err := writer.AddVersion(name, value)
+	if err != nil {
+		return fail("failed to rotate %s: %v", name, err)
+	}
+
+	fmt.Printf("%s rotated to version %s\n", name, version)
+	return subcommands.ExitSuccess
+}
@@ -0,0 +1,67 @@
+// sg-common/cmd/sgsecrets/set.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/google/subcommands"
+)
+
+type setCmd struct{}
+
+func (*setCmd) Name() string     { return "set" }
+func (*setCmd) Synopsis() string { return "add a new version of a secret" }
+func (*setCmd) Usage() string {
+	return "set <name> <value|@file>\n"
+}
+func (*setCmd) SetFlags(*flag.FlagSet) {}
+
+func (*setCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 2 {
+		return fail("set: expected a secret name and a value")
+	}
+
+	name := f.Arg(0)
+	value, err := resolveValue(f.Arg(1))
+	if err != nil {
+		return fail("failed to resolve value: %v", err)
+	}
+
+	writer, err := newWriter()
+	if err != nil {
+		return fail("failed to create gcp writer: %v", err)
+	}
+
+	if err := writer.CreateSecret(name); err != nil {
+		// The secret most likely already exists; proceed to add a version
+		// regardless and let AddVersion surface any real problem.
+		fmt.Fprintf(os.Stderr, "note: create %s: %v\n", name, err)
+	}
+
+	version, err := writer.AddVersion(name, value)
+	if err != nil {
+		return fail("failed to add version: %v", err)
+	}
+
+	fmt.Printf("%s is now at version %s\n", name, version)
+	return subcommands.ExitSuccess
+}
+
+// resolveValue returns raw as-is, unless it starts with "@", in which case
+// the rest is treated as a path to read the value from.
+func resolveValue(raw string) (string, error) {
+	path, ok := strings.CutPrefix(raw, "@")
+	if !ok {
+		return raw, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\n"), nil
+}
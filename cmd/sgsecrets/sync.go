@@ -0,0 +1,63 @@
+// sg-common/cmd/sgsecrets/sync.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/google/subcommands"
+
+	"github.com/PiccoloMondoC/sg-common/secrets"
+)
+
+type syncCmd struct {
+	from string
+	to   string
+}
+
+func (*syncCmd) Name() string     { return "sync" }
+func (*syncCmd) Synopsis() string { return "bulk-migrate secrets from one backend to another" }
+func (*syncCmd) Usage() string    { return "sync --from=env --to=gcp\n" }
+
+func (c *syncCmd) SetFlags(f *flag.FlagSet) {
+	f.StringVar(&c.from, "from", "env", `source backend; only "env" (a .env file) is supported today`)
+	f.StringVar(&c.to, "to", "gcp", `destination backend; only "gcp" is supported today`)
+}
+
+func (c *syncCmd) Execute(_ context.Context, _ *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if c.from != "env" {
+		return fail("sync: unsupported --from %q, only \"env\" is supported", c.from)
+	}
+	if c.to != "gcp" {
+		return fail("sync: unsupported --to %q, only \"gcp\" is supported", c.to)
+	}
+
+	path := os.Getenv("DOTENV_PATH")
+	if path == "" {
+		path = ".env"
+	}
+
+	values, err := secrets.ParseDotEnvFile(path)
+	if err != nil {
+		return fail("sync: failed to read %s: %v", path, err)
+	}
+
+	writer, err := newWriter()
+	if err != nil {
+		return fail("sync: failed to create gcp writer: %v", err)
+	}
+
+	for name, value := range values {
+		if err := writer.CreateSecret(name); err != nil {
+			fmt.Fprintf(os.Stderr, "note: create %s: %v\n", name, err)
+		}
+		if _, err := writer.AddVersion(name, value); err != nil {
+			return fail("sync: failed to sync %s: %v", name, err)
+		}
+		fmt.Printf("synced %s\n", name)
+	}
+
+	return subcommands.ExitSuccess
+}
@@ -0,0 +1,38 @@
+// sg-common/cmd/sgsecrets/versions.go
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/google/subcommands"
+)
+
+type versionsCmd struct{}
+
+func (*versionsCmd) Name() string     { return "versions" }
+func (*versionsCmd) Synopsis() string { return "list the versions of a secret" }
+func (*versionsCmd) Usage() string    { return "versions <name>\n" }
+func (*versionsCmd) SetFlags(*flag.FlagSet) {}
+
+func (*versionsCmd) Execute(_ context.Context, f *flag.FlagSet, _ ...interface{}) subcommands.ExitStatus {
+	if f.NArg() != 1 {
+		return fail("versions: expected exactly one secret name")
+	}
+
+	writer, err := newWriter()
+	if err != nil {
+		return fail("failed to create gcp writer: %v", err)
+	}
+
+	versions, err := writer.ListVersions(f.Arg(0))
+	if err != nil {
+		return fail("failed to list versions: %v", err)
+	}
+
+	for _, version := range versions {
+		fmt.Println(version)
+	}
+	return subcommands.ExitSuccess
+}
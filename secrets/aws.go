@@ -0,0 +1,65 @@
+// sg-common/secrets/aws.go
+/*
+   AwsSecretsManagerFetcher reads secrets from AWS Secrets Manager, so a
+   service can keep using the SecretFetcher interface while its secrets
+   live in AWS instead of (or alongside) GCP Secret Manager.
+*/
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+)
+
+type AwsSecretsManagerFetcher struct {
+	client *secretsmanager.Client
+}
+
+// NewAwsSecretsManagerFetcher builds a fetcher using the default AWS
+// credential chain (env vars, shared config, instance/task role). region
+// may be empty to use whatever the default chain resolves.
+func NewAwsSecretsManagerFetcher(region string) (*AwsSecretsManagerFetcher, error) {
+	ctx := context.Background()
+
+	var opts []func(*config.LoadOptions) error
+	if region != "" {
+		opts = append(opts, config.WithRegion(region))
+	}
+
+	cfg, err := config.LoadDefaultConfig(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load aws config: %v", err)
+	}
+
+	return &AwsSecretsManagerFetcher{
+		client: secretsmanager.NewFromConfig(cfg),
+	}, nil
+}
+
+func (f *AwsSecretsManagerFetcher) GetSecret(ctx context.Context, key string) (string, error) {
+	return instrumentFetch(ctx, "aws", key, func(ctx context.Context) (string, error) {
+		result, err := f.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+			SecretId: aws.String(key),
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to get aws secret value: %v", err)
+		}
+
+		if result.SecretString != nil {
+			return *result.SecretString, nil
+		}
+		return string(result.SecretBinary), nil
+	})
+}
+
+func init() {
+	Register("aws", func(cfg map[string]string) (SecretFetcher, error) {
+		return NewAwsSecretsManagerFetcher(cfg["region"])
+	})
+}
+
+var _ SecretFetcher = (*AwsSecretsManagerFetcher)(nil)
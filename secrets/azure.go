@@ -0,0 +1,58 @@
+// sg-common/secrets/azure.go
+/*
+   AzureKeyVaultFetcher reads secrets from an Azure Key Vault, so a service
+   can keep using the SecretFetcher interface while its secrets live in
+   Azure instead of (or alongside) GCP Secret Manager.
+*/
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/security/keyvault/azsecrets"
+)
+
+type AzureKeyVaultFetcher struct {
+	client *azsecrets.Client
+}
+
+// NewAzureKeyVaultFetcher builds a fetcher against the vault at vaultURL
+// (e.g. "https://my-vault.vault.azure.net"), authenticating with the
+// default Azure credential chain.
+func NewAzureKeyVaultFetcher(vaultURL string) (*AzureKeyVaultFetcher, error) {
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup azure credential: %v", err)
+	}
+
+	client, err := azsecrets.NewClient(vaultURL, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup azure key vault client: %v", err)
+	}
+
+	return &AzureKeyVaultFetcher{client: client}, nil
+}
+
+func (f *AzureKeyVaultFetcher) GetSecret(ctx context.Context, key string) (string, error) {
+	return instrumentFetch(ctx, "azure", key, func(ctx context.Context) (string, error) {
+		resp, err := f.client.GetSecret(ctx, key, "", nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to get azure key vault secret: %v", err)
+		}
+		if resp.Value == nil {
+			return "", fmt.Errorf("azure key vault secret %q has no value", key)
+		}
+
+		return *resp.Value, nil
+	})
+}
+
+func init() {
+	Register("azure", func(cfg map[string]string) (SecretFetcher, error) {
+		return NewAzureKeyVaultFetcher(cfg["vault_url"])
+	})
+}
+
+var _ SecretFetcher = (*AzureKeyVaultFetcher)(nil)
@@ -0,0 +1,227 @@
+// sg-common/secrets/cache.go
+/*
+   CachingSecretFetcher wraps any SecretFetcher with an in-memory cache so
+   that repeated lookups of the same secret don't pay the cost of a network
+   round-trip on every call. Entries expire after a configurable TTL; a
+   background goroutine can refresh them before they expire so that callers
+   never observe a cache miss in steady state. If a refresh fails, the last
+   known good value keeps being served for up to StaleWhileError before the
+   entry is finally treated as gone, and the failure is reported through
+   OnError so operators can alert on it.
+*/
+package secrets
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// CacheConfig controls the behavior of a CachingSecretFetcher.
+type CacheConfig struct {
+	// TTL is how long a cached value is considered fresh. Defaults to 5
+	// minutes if zero.
+	TTL time.Duration
+
+	// RefreshInterval, if non-zero, starts a background goroutine that
+	// refreshes every cached entry on this interval, so entries are kept
+	// warm before they expire. Leave zero to only refresh lazily, on the
+	// next GetSecret call after expiry.
+	RefreshInterval time.Duration
+
+	// StaleWhileError is how long a previously cached value may keep being
+	// served after a refresh attempt fails. Zero means a failed refresh is
+	// returned to the caller immediately.
+	StaleWhileError time.Duration
+
+	// OnError, if set, is called whenever a background refresh fails, and
+	// when a foreground refresh fails and the stale value is served in its
+	// place. A foreground failure that is returned to the caller is not
+	// passed to OnError. key is the secret name and err is the error
+	// returned by the underlying fetcher.
+	OnError func(key string, err error)
+}
+
+type cacheEntry struct {
+	value     string
+	fetchedAt time.Time
+	err       error
+}
+
+// fresh reports whether e's value (not e's most recent error, if any) is
+// still within ttl. A refresh failure records err on the entry without
+// updating fetchedAt, so a value that was good before the failed refresh
+// stays fresh until the TTL it was originally fetched with actually
+// elapses.
+func (e *cacheEntry) fresh(ttl time.Duration) bool {
+	return !e.fetchedAt.IsZero() && time.Since(e.fetchedAt) < ttl
+}
+
+func (e *cacheEntry) stale(ttl, staleWhileError time.Duration) bool {
+	return !e.fetchedAt.IsZero() && time.Since(e.fetchedAt) < ttl+staleWhileError
+}
+
+// CachingSecretFetcher decorates a SecretFetcher with an in-memory, TTL-based
+// cache. Concurrent misses for the same key are coalesced via singleflight
+// so that only one request reaches the underlying fetcher at a time.
+type CachingSecretFetcher struct {
+	inner  SecretFetcher
+	config CacheConfig
+
+	mu      sync.RWMutex
+	entries map[string]*cacheEntry
+	group   singleflight.Group
+
+	stopOnce sync.Once
+	stopCh   chan struct{}
+	wg       sync.WaitGroup
+}
+
+// NewCachingSecretFetcher wraps inner with an in-memory cache governed by
+// config.
+func NewCachingSecretFetcher(inner SecretFetcher, config CacheConfig) *CachingSecretFetcher {
+	if config.TTL <= 0 {
+		config.TTL = 5 * time.Minute
+	}
+
+	c := &CachingSecretFetcher{
+		inner:   inner,
+		config:  config,
+		entries: make(map[string]*cacheEntry),
+		stopCh:  make(chan struct{}),
+	}
+
+	if config.RefreshInterval > 0 {
+		c.wg.Add(1)
+		go c.refreshLoop()
+	}
+
+	return c
+}
+
+func (c *CachingSecretFetcher) GetSecret(ctx context.Context, key string) (string, error) {
+	// Snapshot the entry's fields under the read lock rather than holding
+	// on to the *cacheEntry itself: store() never mutates an entry in
+	// place (it always replaces the map value), so this copy can safely
+	// be read afterwards without racing a concurrent store().
+	entry, ok := c.snapshot(key)
+
+	if ok && entry.fresh(c.config.TTL) {
+		return entry.value, nil
+	}
+
+	value, err, _ := c.group.Do(key, func() (interface{}, error) {
+		return c.fetchAndStore(ctx, key)
+	})
+	if err != nil {
+		if ok && entry.stale(c.config.TTL, c.config.StaleWhileError) {
+			c.reportError(key, err)
+			return entry.value, nil
+		}
+		return "", err
+	}
+
+	return value.(string), nil
+}
+
+func (c *CachingSecretFetcher) snapshot(key string) (cacheEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return cacheEntry{}, false
+	}
+	return *entry, true
+}
+
+func (c *CachingSecretFetcher) fetchAndStore(ctx context.Context, key string) (string, error) {
+	value, err := c.inner.GetSecret(ctx, key)
+	c.store(key, value, err)
+	return value, err
+}
+
+func (c *CachingSecretFetcher) store(key, value string, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		// Keep serving the last known good value untouched; only record
+		// the error for diagnostics. fetchedAt is deliberately carried
+		// over (or left zero, if there was never a good value) so
+		// fresh/stale keep measuring from the last successful fetch, not
+		// from this failure. Always replace the map entry with a new
+		// struct rather than mutating the existing one in place, so a
+		// stored *cacheEntry is never written again.
+		next := cacheEntry{err: err}
+		if existing, ok := c.entries[key]; ok {
+			next.value = existing.value
+			next.fetchedAt = existing.fetchedAt
+		}
+		c.entries[key] = &next
+		return
+	}
+
+	c.entries[key] = &cacheEntry{value: value, fetchedAt: time.Now()}
+}
+
+func (c *CachingSecretFetcher) reportError(key string, err error) {
+	if c.config.OnError != nil {
+		c.config.OnError(key, err)
+	}
+}
+
+func (c *CachingSecretFetcher) refreshLoop() {
+	defer c.wg.Done()
+
+	ticker := time.NewTicker(c.config.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.refreshAll()
+		case <-c.stopCh:
+			return
+		}
+	}
+}
+
+func (c *CachingSecretFetcher) refreshAll() {
+	c.mu.RLock()
+	keys := make([]string, 0, len(c.entries))
+	for k := range c.entries {
+		keys = append(keys, k)
+	}
+	c.mu.RUnlock()
+
+	for _, key := range keys {
+		if _, err, _ := c.group.Do(key, func() (interface{}, error) {
+			return c.fetchAndStore(context.Background(), key)
+		}); err != nil {
+			c.reportError(key, err)
+		}
+	}
+}
+
+// Invalidate removes key from the cache so that the next GetSecret call
+// fetches a fresh value from the underlying fetcher.
+func (c *CachingSecretFetcher) Invalidate(key string) {
+	c.mu.Lock()
+	delete(c.entries, key)
+	c.mu.Unlock()
+}
+
+// Close stops the background refresh goroutine, if any. It is safe to call
+// Close more than once.
+func (c *CachingSecretFetcher) Close() error {
+	c.stopOnce.Do(func() {
+		close(c.stopCh)
+	})
+	c.wg.Wait()
+	return nil
+}
+
+var _ SecretFetcher = (*CachingSecretFetcher)(nil)
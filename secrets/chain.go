@@ -0,0 +1,45 @@
+// sg-common/secrets/chain.go
+/*
+   ChainFetcher lets a service consume secrets from several backends at
+   once during a migration, without having to decide upfront which backend
+   owns a given key.
+*/
+package secrets
+
+import (
+	"context"
+	"fmt"
+)
+
+// ChainFetcher tries each of its fetchers in order and returns the first
+// one that produces a non-empty value.
+type ChainFetcher struct {
+	fetchers []SecretFetcher
+}
+
+// NewChainFetcher returns a fetcher that tries each of fetchers in order.
+func NewChainFetcher(fetchers ...SecretFetcher) *ChainFetcher {
+	return &ChainFetcher{fetchers: fetchers}
+}
+
+func (f *ChainFetcher) GetSecret(ctx context.Context, key string) (string, error) {
+	var lastErr error
+
+	for _, fetcher := range f.fetchers {
+		value, err := fetcher.GetSecret(ctx, key)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if value != "" {
+			return value, nil
+		}
+	}
+
+	if lastErr != nil {
+		return "", fmt.Errorf("no provider returned secret %q: %w", key, lastErr)
+	}
+	return "", fmt.Errorf("no provider returned secret %q: %w", key, ErrNotFound)
+}
+
+var _ SecretFetcher = (*ChainFetcher)(nil)
@@ -0,0 +1,93 @@
+// sg-common/secrets/dotenv.go
+/*
+   DotEnvFetcher reads secrets out of a ".env"-style file (KEY=VALUE per
+   line). It's useful as a migration stepping stone: a microservice can
+   point at a checked-out .env file locally and a real secret store in
+   production, without changing any calling code.
+*/
+package secrets
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type DotEnvFetcher struct {
+	values map[string]string
+}
+
+// NewDotEnvFetcher parses path (defaults to ".env" if empty) and returns a
+// fetcher over its key/value pairs.
+func NewDotEnvFetcher(path string) (*DotEnvFetcher, error) {
+	if path == "" {
+		path = ".env"
+	}
+
+	values, err := parseDotEnvFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DotEnvFetcher{values: values}, nil
+}
+
+func (f *DotEnvFetcher) GetSecret(ctx context.Context, key string) (string, error) {
+	return instrumentFetch(ctx, "dotenv", key, func(ctx context.Context) (string, error) {
+		value, ok := f.values[key]
+		if !ok {
+			return "", fmt.Errorf("secret %q not found in dotenv file", key)
+		}
+		return value, nil
+	})
+}
+
+// ParseDotEnvFile parses a ".env"-style file and returns its key/value
+// pairs. It's exported so callers such as cmd/sgsecrets can enumerate
+// entries directly, rather than look up one key at a time through
+// DotEnvFetcher.
+func ParseDotEnvFile(path string) (map[string]string, error) {
+	return parseDotEnvFile(path)
+}
+
+func parseDotEnvFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open dotenv file: %v", err)
+	}
+	defer file.Close()
+
+	values := make(map[string]string)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key := strings.TrimSpace(parts[0])
+		value := strings.Trim(strings.TrimSpace(parts[1]), `"'`)
+		values[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read dotenv file: %v", err)
+	}
+
+	return values, nil
+}
+
+func init() {
+	Register("dotenv", func(cfg map[string]string) (SecretFetcher, error) {
+		return NewDotEnvFetcher(cfg["path"])
+	})
+}
+
+var _ SecretFetcher = (*DotEnvFetcher)(nil)
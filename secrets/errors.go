@@ -0,0 +1,81 @@
+// sg-common/secrets/errors.go
+/*
+   Every backend used to return raw fmt.Errorf strings, which meant callers
+   had no way to tell a permanent failure (the secret doesn't exist) apart
+   from a transient one (Secret Manager had a bad minute) without parsing
+   error text. This file defines sentinel errors, and the gRPC status
+   mapping GcpSecretManagerFetcher uses to wrap its errors in the right
+   one. The AWS, Vault, Azure and dotenv fetchers return their errors
+   unwrapped, so none of these sentinels matches them under errors.Is.
+*/
+package secrets
+
+import (
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrNotFound means the secret or secret version does not exist.
+	ErrNotFound = errors.New("secrets: not found")
+
+	// ErrPermissionDenied means the caller is authenticated but not
+	// authorized to read the secret.
+	ErrPermissionDenied = errors.New("secrets: permission denied")
+
+	// ErrUnauthenticated means the caller's credentials were missing or
+	// rejected.
+	ErrUnauthenticated = errors.New("secrets: unauthenticated")
+
+	// ErrTransient means the backend failed in a way that is likely to
+	// succeed on retry (e.g. unavailable, timed out, internal error).
+	ErrTransient = errors.New("secrets: transient error, safe to retry")
+)
+
+// wrapGcpError maps a gRPC status error returned by the Secret Manager
+// client to one of this package's sentinel errors via errors.Is, so
+// callers don't have to parse error strings to tell failure modes apart.
+// Errors that don't carry a gRPC status are returned unchanged.
+func wrapGcpError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	switch st.Code() {
+	case codes.NotFound:
+		return fmt.Errorf("%w: %v", ErrNotFound, err)
+	case codes.PermissionDenied:
+		return fmt.Errorf("%w: %v", ErrPermissionDenied, err)
+	case codes.Unauthenticated:
+		return fmt.Errorf("%w: %v", ErrUnauthenticated, err)
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Internal:
+		return fmt.Errorf("%w: %v", ErrTransient, err)
+	default:
+		return err
+	}
+}
+
+// errorCode returns a short, stable label for err, suitable for use as a
+// metrics label value.
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return "not_found"
+	case errors.Is(err, ErrPermissionDenied):
+		return "permission_denied"
+	case errors.Is(err, ErrUnauthenticated):
+		return "unauthenticated"
+	case errors.Is(err, ErrTransient):
+		return "transient"
+	default:
+		return "unknown"
+	}
+}
@@ -0,0 +1,76 @@
+// sg-common/secrets/observability.go
+/*
+   Secret-store degradation used to be invisible until a service started
+   failing for unrelated-looking reasons. This file wraps backend calls
+   with an OpenTelemetry span and Prometheus metrics operators can alert on:
+   sg_secrets_fetch_duration_seconds and sg_secrets_fetch_errors_total.
+
+   As a shared library, this package must not force-register collectors on
+   the default Prometheus registry on import: two services (or a service
+   and its test binary) importing secrets would otherwise panic on a
+   duplicate collector name. Metrics are created eagerly but left
+   unregistered; call RegisterMetrics with whatever *prometheus.Registry
+   the importing service already uses to opt in.
+*/
+package secrets
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var tracer = otel.Tracer("sg-common/secrets")
+
+var (
+	fetchDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name: "sg_secrets_fetch_duration_seconds",
+		Help: "Time spent fetching a secret from the underlying backend.",
+	}, []string{"backend"})
+
+	fetchErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "sg_secrets_fetch_errors_total",
+		Help: "Count of failed secret fetches, by sentinel error code.",
+	}, []string{"backend", "code"})
+)
+
+// RegisterMetrics registers this package's Prometheus collectors
+// (sg_secrets_fetch_duration_seconds, sg_secrets_fetch_errors_total)
+// against reg. Call it once, during startup, with the service's own
+// registry (or prometheus.DefaultRegisterer, if that's what the service
+// exposes on /metrics); GetSecret works without it, it just won't be
+// observable until RegisterMetrics is called.
+func RegisterMetrics(reg prometheus.Registerer) error {
+	if err := reg.Register(fetchDuration); err != nil {
+		return err
+	}
+	return reg.Register(fetchErrors)
+}
+
+// instrumentFetch runs fn inside an OpenTelemetry span tagged with backend
+// and key, and records its duration and any error against the Prometheus
+// metrics above.
+func instrumentFetch(ctx context.Context, backend string, key string, fn func(context.Context) (string, error)) (string, error) {
+	ctx, span := tracer.Start(ctx, "secrets.GetSecret", trace.WithAttributes(
+		attribute.String("secrets.backend", backend),
+		attribute.String("secrets.key", key),
+	))
+	defer span.End()
+
+	start := time.Now()
+	value, err := fn(ctx)
+	fetchDuration.WithLabelValues(backend).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		fetchErrors.WithLabelValues(backend, errorCode(err)).Inc()
+	}
+
+	return value, err
+}
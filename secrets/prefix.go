@@ -0,0 +1,52 @@
+// sg-common/secrets/prefix.go
+/*
+   PrefixRouter dispatches a lookup to a different backend depending on the
+   key's prefix (e.g. "vault:db/password", "aws:prod/api-key"), so a single
+   microservice can read from heterogeneous secret stores during a
+   migration without threading that decision through its own code.
+*/
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// PrefixRouter routes GetSecret calls to a registered fetcher based on the
+// "prefix:" portion of the key. Keys with no matching prefix fall back to
+// defaultFetcher, if set.
+type PrefixRouter struct {
+	routes         map[string]SecretFetcher
+	defaultFetcher SecretFetcher
+}
+
+// NewPrefixRouter returns a router that falls back to defaultFetcher for
+// keys with no registered prefix. defaultFetcher may be nil.
+func NewPrefixRouter(defaultFetcher SecretFetcher) *PrefixRouter {
+	return &PrefixRouter{
+		routes:         make(map[string]SecretFetcher),
+		defaultFetcher: defaultFetcher,
+	}
+}
+
+// Register associates prefix (without its trailing ":") with fetcher.
+func (r *PrefixRouter) Register(prefix string, fetcher SecretFetcher) {
+	r.routes[prefix] = fetcher
+}
+
+func (r *PrefixRouter) GetSecret(ctx context.Context, key string) (string, error) {
+	if prefix, rest, ok := strings.Cut(key, ":"); ok {
+		if fetcher, found := r.routes[prefix]; found {
+			return fetcher.GetSecret(ctx, rest)
+		}
+	}
+
+	if r.defaultFetcher != nil {
+		return r.defaultFetcher.GetSecret(ctx, key)
+	}
+
+	return "", fmt.Errorf("no fetcher registered for prefix of key %q: %w", key, ErrNotFound)
+}
+
+var _ SecretFetcher = (*PrefixRouter)(nil)
@@ -0,0 +1,47 @@
+// sg-common/secrets/registry.go
+/*
+   GetFetcher used to pick between EnvVarSecretFetcher and
+   GcpSecretManagerFetcher via a single USE_SECRET_MANAGER boolean. As more
+   backends were added (AWS, Vault, Azure, dotenv), that boolean doesn't
+   scale. This file introduces a small registry so each backend can be
+   selected by name, and new backends can register themselves from their
+   own file without GetFetcher needing to know about them.
+*/
+package secrets
+
+import "fmt"
+
+// FetcherFactory builds a SecretFetcher from a provider-specific config
+// map. What keys a factory reads from cfg is up to the provider (see the
+// Register calls in aws.go, vault.go, azure.go and dotenv.go).
+type FetcherFactory func(cfg map[string]string) (SecretFetcher, error)
+
+var registry = map[string]FetcherFactory{}
+
+// Register makes a SecretFetcher backend available under name, for use
+// with NewFetcher and GetFetcher. It is typically called from an init()
+// function in the backend's own file. Registering the same name twice
+// overwrites the previous factory.
+func Register(name string, factory FetcherFactory) {
+	registry[name] = factory
+}
+
+// NewFetcher builds the SecretFetcher registered under name, passing it
+// cfg. It returns an error if no backend is registered under that name.
+func NewFetcher(name string, cfg map[string]string) (SecretFetcher, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("no secret fetcher registered under name %q", name)
+	}
+	return factory(cfg)
+}
+
+func init() {
+	Register("env", func(cfg map[string]string) (SecretFetcher, error) {
+		return &EnvVarSecretFetcher{}, nil
+	})
+
+	Register("gcp", func(cfg map[string]string) (SecretFetcher, error) {
+		return NewGcpSecretManagerFetcher(cfg["project_id"], cfg["credentials_file"])
+	})
+}
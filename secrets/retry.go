@@ -0,0 +1,91 @@
+// sg-common/secrets/retry.go
+/*
+   GcpSecretManagerFetcher used to make a single, un-retried call per
+   GetSecret. A Secret Manager blip (Unavailable, DeadlineExceeded,
+   Internal) would surface straight to the caller even though retrying a
+   moment later usually succeeds. This file adds a small exponential
+   backoff helper that only retries errors wrapped in ErrTransient.
+*/
+package secrets
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+)
+
+// RetryConfig controls GcpSecretManagerFetcher's retry behavior.
+type RetryConfig struct {
+	// MaxAttempts is the total number of attempts, including the first.
+	// Defaults to 4 if zero.
+	MaxAttempts int
+
+	// BaseDelay is the upper bound of the jittered delay before the second
+	// attempt; the bound doubles after each subsequent attempt, capped at
+	// MaxDelay. Defaults to 200ms.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff delay. Defaults to 5s.
+	MaxDelay time.Duration
+}
+
+var defaultRetryConfig = RetryConfig{
+	MaxAttempts: 4,
+	BaseDelay:   200 * time.Millisecond,
+	MaxDelay:    5 * time.Second,
+}
+
+func (c RetryConfig) withDefaults() RetryConfig {
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = defaultRetryConfig.MaxAttempts
+	}
+	if c.BaseDelay <= 0 {
+		c.BaseDelay = defaultRetryConfig.BaseDelay
+	}
+	if c.MaxDelay <= 0 {
+		c.MaxDelay = defaultRetryConfig.MaxDelay
+	}
+	return c
+}
+
+// withRetry calls fn until it succeeds, returns a non-transient error, ctx
+// is done, or cfg.MaxAttempts is exhausted, waiting an exponentially
+// growing, jittered delay between attempts.
+func withRetry(ctx context.Context, cfg RetryConfig, fn func() error) error {
+	cfg = cfg.withDefaults()
+
+	var err error
+	for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
+		err = fn()
+		if err == nil || !errors.Is(err, ErrTransient) {
+			return err
+		}
+		if attempt == cfg.MaxAttempts-1 {
+			break
+		}
+
+		select {
+		case <-time.After(backoffDelay(cfg, attempt)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return err
+}
+
+// backoffDelay returns a jittered exponential delay for the given attempt
+// number (0-indexed).
+func backoffDelay(cfg RetryConfig, attempt int) time.Duration {
+	// BaseDelay << attempt can overflow int64 well before MaxAttempts is
+	// reached, wrapping the Duration negative and making rand.Int63n panic
+	// on a negative argument. Only trust the shifted value when it's still
+	// a smaller, positive delay than the cap; otherwise just use the cap.
+	delay := cfg.MaxDelay
+	if scaled := cfg.BaseDelay * time.Duration(1<<uint(attempt)); scaled > 0 && scaled < delay {
+		delay = scaled
+	}
+	// Full jitter: a random delay between 0 and the computed cap.
+	return time.Duration(rand.Int63n(int64(delay) + 1))
+}
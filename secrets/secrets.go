@@ -23,6 +23,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
+	"time"
 
 	secretmanager "cloud.google.com/go/secretmanager/apiv1"
 	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
@@ -30,12 +32,23 @@ import (
 )
 
 type SecretFetcher interface {
-	GetSecret(key string) (string, error)
+	GetSecret(ctx context.Context, key string) (string, error)
+}
+
+// GetSecretCompat calls f.GetSecret with context.Background(), for callers
+// that haven't been updated to pass a context yet. It's a migration aid
+// for the one release in which SecretFetcher's signature changed from
+// GetSecret(key string) to GetSecret(ctx, key); callers should move to
+// calling GetSecret directly and pass their own context.
+//
+// Deprecated: call SecretFetcher.GetSecret with a real context instead.
+func GetSecretCompat(f SecretFetcher, key string) (string, error) {
+	return f.GetSecret(context.Background(), key)
 }
 
 type EnvVarSecretFetcher struct{}
 
-func (f *EnvVarSecretFetcher) GetSecret(key string) (string, error) {
+func (f *EnvVarSecretFetcher) GetSecret(ctx context.Context, key string) (string, error) {
 	// Fetch secret from environment variable
 	return os.Getenv(key), nil
 }
@@ -43,6 +56,14 @@ func (f *EnvVarSecretFetcher) GetSecret(key string) (string, error) {
 type GcpSecretManagerFetcher struct {
 	client    *secretmanager.Client
 	projectID string
+
+	// watchInterval is how often WatchSecret polls for version changes.
+	// Defaults to defaultWatchInterval.
+	watchInterval time.Duration
+
+	// retryConfig governs retries of transient errors. Defaults to
+	// defaultRetryConfig.
+	retryConfig RetryConfig
 }
 
 func NewGcpSecretManagerFetcher(projectID string, credentialsFile string) (*GcpSecretManagerFetcher, error) {
@@ -54,49 +75,212 @@ func NewGcpSecretManagerFetcher(projectID string, credentialsFile string) (*GcpS
 	}
 
 	return &GcpSecretManagerFetcher{
-		client:    client,
-		projectID: projectID,
+		client:        client,
+		projectID:     projectID,
+		watchInterval: defaultWatchInterval,
+		retryConfig:   defaultRetryConfig,
 	}, nil
 }
 
-func (f *GcpSecretManagerFetcher) GetSecret(secretID string) (string, error) {
-	ctx := context.Background()
+// SetWatchInterval overrides the polling interval used by WatchSecret.
+func (f *GcpSecretManagerFetcher) SetWatchInterval(interval time.Duration) {
+	f.watchInterval = interval
+}
 
-	// Build the request.
-	accessRequest := &secretmanagerpb.AccessSecretVersionRequest{
-		Name: fmt.Sprintf("projects/%s/secrets/%s/versions/latest", f.projectID, secretID),
-	}
+// SetRetryConfig overrides the retry behavior used by GetSecret and
+// GetSecretVersion.
+func (f *GcpSecretManagerFetcher) SetRetryConfig(config RetryConfig) {
+	f.retryConfig = config
+}
 
-	// Call the API.
-	result, err := f.client.AccessSecretVersion(ctx, accessRequest)
-	if err != nil {
-		return "", fmt.Errorf("failed to access secret version: %v", err)
+func (f *GcpSecretManagerFetcher) GetSecret(ctx context.Context, secretID string) (string, error) {
+	// Allow the "name@version" shorthand (e.g. "API_KEY@3",
+	// "API_KEY@latest") so callers can pin a specific version inline
+	// without reaching for GetSecretVersion directly.
+	name, version := splitNameVersion(secretID)
+	return f.GetSecretVersion(ctx, name, version)
+}
+
+// GetSecretVersion fetches secretID at a specific version, which may be a
+// version number (e.g. "3") or the alias "latest". It retries transient
+// backend errors (Unavailable, DeadlineExceeded, Internal) with
+// exponential backoff, per f.retryConfig.
+func (f *GcpSecretManagerFetcher) GetSecretVersion(ctx context.Context, secretID string, version string) (string, error) {
+	if version == "" {
+		version = "latest"
 	}
 
-	// Return the secret payload as a string.
-	return string(result.Payload.Data), nil
+	return instrumentFetch(ctx, "gcp", secretID, func(ctx context.Context) (string, error) {
+		var payload string
+		err := withRetry(ctx, f.retryConfig, func() error {
+			accessRequest := &secretmanagerpb.AccessSecretVersionRequest{
+				Name: fmt.Sprintf("projects/%s/secrets/%s/versions/%s", f.projectID, secretID, version),
+			}
+
+			result, err := f.client.AccessSecretVersion(ctx, accessRequest)
+			if err != nil {
+				return wrapGcpError(err)
+			}
+
+			payload = string(result.Payload.Data)
+			return nil
+		})
+		return payload, err
+	})
 }
 
+// splitNameVersion splits the "name@version" shorthand into its parts. If
+// key does not contain "@", version is returned empty.
+func splitNameVersion(key string) (name string, version string) {
+	if idx := strings.LastIndex(key, "@"); idx != -1 {
+		return key[:idx], key[idx+1:]
+	}
+	return key, ""
+}
+
+// GetFetcher builds the SecretFetcher to use, as configured by env vars
+// (see providerConfigFromEnv and cacheTTLFromEnv). If SECRET_CACHE_REFRESH
+// is set, the returned fetcher owns a background refresh goroutine; call
+// CloseFetcher on it during shutdown to stop that goroutine. SECRET_CACHE_STALE
+// lets a cached value keep being served for a while after a refresh starts
+// failing, rather than invalidating it the moment its TTL passes; failures
+// during that window are logged. Services that need their own OnError
+// handling (e.g. paging instead of logging) should construct a
+// CachingSecretFetcher directly instead of going through GetFetcher.
 func GetFetcher() SecretFetcher {
-	useSecretManager := os.Getenv("USE_SECRET_MANAGER")
-	if useSecretManager == "true" {
-		projectID := os.Getenv("GCP_PROJECT_ID")
-		credentialsFile := os.Getenv("GCP_CREDENTIALS_FILE")
-		fetcher, err := NewGcpSecretManagerFetcher(projectID, credentialsFile)
+	fetcher := legacyFetcherFromEnv()
+
+	if provider := os.Getenv("SECRET_PROVIDER"); provider != "" {
+		providerFetcher, err := NewFetcher(provider, providerConfigFromEnv())
 		if err != nil {
-			log.Printf("Failed to create GcpSecretManagerFetcher: %v", err)
+			log.Printf("Failed to create %q secret fetcher: %v", provider, err)
 			log.Println("Falling back to EnvVarSecretFetcher")
 		} else {
-			return fetcher
+			fetcher = providerFetcher
 		}
 	}
-	return &EnvVarSecretFetcher{}
+
+	if ttl, ok := cacheTTLFromEnv(); ok {
+		fetcher = NewCachingSecretFetcher(fetcher, CacheConfig{
+			TTL:             ttl,
+			RefreshInterval: cacheRefreshFromEnv(),
+			StaleWhileError: cacheStaleWhileErrorFromEnv(),
+			OnError: func(key string, err error) {
+				log.Printf("secrets: serving %q from cache after a refresh error: %v", key, err)
+			},
+		})
+	}
+
+	return fetcher
+}
+
+// CloseFetcher releases any resources held by fetcher, such as a
+// CachingSecretFetcher's background refresh goroutine. GetFetcher returns
+// a plain SecretFetcher so callers aren't coupled to which decorators are
+// active, so shutdown code should call CloseFetcher on whatever it got
+// back rather than type-asserting for *CachingSecretFetcher itself.
+// Fetchers that don't need explicit cleanup are left untouched.
+func CloseFetcher(fetcher SecretFetcher) error {
+	if closer, ok := fetcher.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// legacyFetcherFromEnv reproduces GetFetcher's original USE_SECRET_MANAGER
+// behavior, kept for services that set it without also setting
+// SECRET_PROVIDER.
+func legacyFetcherFromEnv() SecretFetcher {
+	if os.Getenv("USE_SECRET_MANAGER") != "true" {
+		return &EnvVarSecretFetcher{}
+	}
+
+	projectID := os.Getenv("GCP_PROJECT_ID")
+	credentialsFile := os.Getenv("GCP_CREDENTIALS_FILE")
+	fetcher, err := NewGcpSecretManagerFetcher(projectID, credentialsFile)
+	if err != nil {
+		log.Printf("Failed to create GcpSecretManagerFetcher: %v", err)
+		log.Println("Falling back to EnvVarSecretFetcher")
+		return &EnvVarSecretFetcher{}
+	}
+	return fetcher
+}
+
+// providerConfigFromEnv builds the cfg map passed to a registered
+// FetcherFactory from whatever backend-specific env vars are set. Each
+// backend only reads the keys it understands (see the Register calls in
+// aws.go, vault.go, azure.go and dotenv.go).
+func providerConfigFromEnv() map[string]string {
+	return map[string]string{
+		"project_id":       os.Getenv("GCP_PROJECT_ID"),
+		"credentials_file": os.Getenv("GCP_CREDENTIALS_FILE"),
+		"region":           os.Getenv("AWS_REGION"),
+		"address":          os.Getenv("VAULT_ADDR"),
+		"token":            os.Getenv("VAULT_TOKEN"),
+		"mount_path":       os.Getenv("VAULT_MOUNT_PATH"),
+		"vault_url":        os.Getenv("AZURE_VAULT_URL"),
+		"path":             os.Getenv("DOTENV_PATH"),
+	}
+}
+
+// cacheTTLFromEnv reports the caching TTL requested via SECRET_CACHE_TTL
+// (e.g. "5m", "30s"), and whether caching was requested at all.
+func cacheTTLFromEnv() (time.Duration, bool) {
+	raw := os.Getenv("SECRET_CACHE_TTL")
+	if raw == "" {
+		return 0, false
+	}
+
+	ttl, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Printf("Invalid SECRET_CACHE_TTL %q, ignoring: %v", raw, err)
+		return 0, false
+	}
+
+	return ttl, true
+}
+
+// cacheRefreshFromEnv reports the background refresh interval requested via
+// SECRET_CACHE_REFRESH (e.g. "1m"). Zero means no background refresh.
+func cacheRefreshFromEnv() time.Duration {
+	raw := os.Getenv("SECRET_CACHE_REFRESH")
+	if raw == "" {
+		return 0
+	}
+
+	interval, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Printf("Invalid SECRET_CACHE_REFRESH %q, ignoring: %v", raw, err)
+		return 0
+	}
+
+	return interval
+}
+
+// cacheStaleWhileErrorFromEnv reports how long a cached value may keep
+// being served after a failed refresh, as requested via SECRET_CACHE_STALE
+// (e.g. "10m"). Zero means a refresh failure invalidates the cache
+// immediately once its TTL has passed.
+func cacheStaleWhileErrorFromEnv() time.Duration {
+	raw := os.Getenv("SECRET_CACHE_STALE")
+	if raw == "" {
+		return 0
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Printf("Invalid SECRET_CACHE_STALE %q, ignoring: %v", raw, err)
+		return 0
+	}
+
+	return d
 }
 
 /*
     To fetch the secrets in the application, we can use the code below:
 
 	secretFetcher := secrets.GetFetcher()
-	apiKey, err := secretFetcher.GetSecret("API_KEY") // eg. to a secret named "API_KEY"
+	defer secrets.CloseFetcher(secretFetcher)
+	apiKey, err := secretFetcher.GetSecret(ctx, "API_KEY") // eg. to a secret named "API_KEY"
 
 */
@@ -0,0 +1,64 @@
+// sg-common/secrets/vault.go
+/*
+   VaultFetcher reads secrets from a HashiCorp Vault KV version 2 secrets
+   engine, so a service can keep using the SecretFetcher interface while
+   its secrets live in Vault instead of (or alongside) GCP Secret Manager.
+*/
+package secrets
+
+import (
+	"context"
+	"fmt"
+
+	vault "github.com/hashicorp/vault/api"
+)
+
+type VaultFetcher struct {
+	client    *vault.Client
+	mountPath string
+}
+
+// NewVaultFetcher builds a fetcher that reads from the KV v2 engine
+// mounted at mountPath (defaults to "secret" if empty), authenticating
+// with token.
+func NewVaultFetcher(address string, token string, mountPath string) (*VaultFetcher, error) {
+	config := vault.DefaultConfig()
+	config.Address = address
+
+	client, err := vault.NewClient(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to setup vault client: %v", err)
+	}
+	client.SetToken(token)
+
+	if mountPath == "" {
+		mountPath = "secret"
+	}
+
+	return &VaultFetcher{client: client, mountPath: mountPath}, nil
+}
+
+// GetSecret reads key as a path within the KV v2 mount and returns its
+// "value" field.
+func (f *VaultFetcher) GetSecret(ctx context.Context, key string) (string, error) {
+	return instrumentFetch(ctx, "vault", key, func(ctx context.Context) (string, error) {
+		secret, err := f.client.KVv2(f.mountPath).Get(ctx, key)
+		if err != nil {
+			return "", fmt.Errorf("failed to read vault secret: %v", err)
+		}
+
+		value, ok := secret.Data["value"].(string)
+		if !ok {
+			return "", fmt.Errorf("vault secret %q has no string \"value\" field", key)
+		}
+		return value, nil
+	})
+}
+
+func init() {
+	Register("vault", func(cfg map[string]string) (SecretFetcher, error) {
+		return NewVaultFetcher(cfg["address"], cfg["token"], cfg["mount_path"])
+	})
+}
+
+var _ SecretFetcher = (*VaultFetcher)(nil)
@@ -0,0 +1,170 @@
+// sg-common/secrets/writer.go
+/*
+   Everything in this package so far is read-only: GetFetcher and friends
+   only ever call AccessSecretVersion and ListSecretVersions. Tooling like
+   cmd/sgsecrets also
+   needs to create secrets, add versions, and enable/disable/destroy them,
+   so this file defines the write-side of the GCP Secret Manager API as
+   its own interface, separate from SecretFetcher.
+*/
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/api/iterator"
+)
+
+// SecretWriter is implemented by backends that support mutating secrets,
+// not just reading them.
+type SecretWriter interface {
+	CreateSecret(name string) error
+	AddVersion(name string, value string) (version string, err error)
+	ListSecrets(filter string) ([]string, error)
+	ListVersions(name string) ([]string, error)
+	DestroyVersion(name string, version string) error
+	EnableVersion(name string, version string) error
+	DisableVersion(name string, version string) error
+}
+
+func (f *GcpSecretManagerFetcher) CreateSecret(name string) error {
+	ctx := context.Background()
+
+	_, err := f.client.CreateSecret(ctx, &secretmanagerpb.CreateSecretRequest{
+		Parent:   fmt.Sprintf("projects/%s", f.projectID),
+		SecretId: name,
+		Secret: &secretmanagerpb.Secret{
+			Replication: &secretmanagerpb.Replication{
+				Replication: &secretmanagerpb.Replication_Automatic_{
+					Automatic: &secretmanagerpb.Replication_Automatic{},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create secret %q: %v", name, err)
+	}
+	return nil
+}
+
+// AddVersion adds value as a new version of name and returns the new
+// version's number.
+func (f *GcpSecretManagerFetcher) AddVersion(name string, value string) (string, error) {
+	ctx := context.Background()
+
+	result, err := f.client.AddSecretVersion(ctx, &secretmanagerpb.AddSecretVersionRequest{
+		Parent: fmt.Sprintf("projects/%s/secrets/%s", f.projectID, name),
+		Payload: &secretmanagerpb.SecretPayload{
+			Data: []byte(value),
+		},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to add version to secret %q: %v", name, err)
+	}
+
+	parts := versionParts(result.Name)
+	return parts, nil
+}
+
+// ListSecrets returns the names of every secret in the project. filter, if
+// non-empty, is passed through to Secret Manager's filter syntax.
+func (f *GcpSecretManagerFetcher) ListSecrets(filter string) ([]string, error) {
+	ctx := context.Background()
+
+	it := f.client.ListSecrets(ctx, &secretmanagerpb.ListSecretsRequest{
+		Parent: fmt.Sprintf("projects/%s", f.projectID),
+		Filter: filter,
+	})
+
+	var names []string
+	for {
+		secret, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list secrets: %v", err)
+		}
+		names = append(names, secretNameFromResource(secret.Name))
+	}
+
+	return names, nil
+}
+
+// ListVersions returns the version numbers of name, newest first.
+func (f *GcpSecretManagerFetcher) ListVersions(name string) ([]string, error) {
+	ctx := context.Background()
+
+	it := f.client.ListSecretVersions(ctx, &secretmanagerpb.ListSecretVersionsRequest{
+		Parent: fmt.Sprintf("projects/%s/secrets/%s", f.projectID, name),
+	})
+
+	var versions []string
+	for {
+		v, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list versions of secret %q: %v", name, err)
+		}
+		versions = append(versions, versionParts(v.Name))
+	}
+
+	return versions, nil
+}
+
+func (f *GcpSecretManagerFetcher) DestroyVersion(name string, version string) error {
+	ctx := context.Background()
+
+	_, err := f.client.DestroySecretVersion(ctx, &secretmanagerpb.DestroySecretVersionRequest{
+		Name: fmt.Sprintf("projects/%s/secrets/%s/versions/%s", f.projectID, name, version),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to destroy version %s of secret %q: %v", version, name, err)
+	}
+	return nil
+}
+
+func (f *GcpSecretManagerFetcher) EnableVersion(name string, version string) error {
+	ctx := context.Background()
+
+	_, err := f.client.EnableSecretVersion(ctx, &secretmanagerpb.EnableSecretVersionRequest{
+		Name: fmt.Sprintf("projects/%s/secrets/%s/versions/%s", f.projectID, name, version),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to enable version %s of secret %q: %v", version, name, err)
+	}
+	return nil
+}
+
+func (f *GcpSecretManagerFetcher) DisableVersion(name string, version string) error {
+	ctx := context.Background()
+
+	_, err := f.client.DisableSecretVersion(ctx, &secretmanagerpb.DisableSecretVersionRequest{
+		Name: fmt.Sprintf("projects/%s/secrets/%s/versions/%s", f.projectID, name, version),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to disable version %s of secret %q: %v", version, name, err)
+	}
+	return nil
+}
+
+// secretNameFromResource extracts the secret ID from a fully qualified
+// "projects/P/secrets/NAME" resource name.
+func secretNameFromResource(resource string) string {
+	parts := strings.Split(resource, "/secrets/")
+	return parts[len(parts)-1]
+}
+
+// versionParts extracts the version number from a fully qualified
+// "projects/P/secrets/NAME/versions/N" resource name.
+func versionParts(resource string) string {
+	parts := strings.Split(resource, "/versions/")
+	return parts[len(parts)-1]
+}
+
+var _ SecretWriter = (*GcpSecretManagerFetcher)(nil)